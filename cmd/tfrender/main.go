@@ -0,0 +1,237 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alileza/tfrender"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// debounceDelay absorbs bursts of write events that editors and IDEs often
+// produce for a single save.
+const debounceDelay = 100 * time.Millisecond
+
+// stringSliceFlag collects the values of a repeatable flag, e.g.
+// `-var-file a.tfvars -var-file b.tfvars`, in the order they were given.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringSliceFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func main() {
+	rootDir := flag.String("rootDir", "./", "The root directory to search for .tfvars and .tf files")
+	watch := flag.Bool("watch", false, "After rendering, watch .tfvars files for changes and re-render automatically")
+	dryRun := flag.Bool("dry-run", false, "Print a unified diff of what would change instead of writing files, and exit non-zero if anything would change")
+	check := flag.Bool("check", false, "Like -dry-run but only reports whether rendering would be a no-op, without printing a diff")
+
+	var varFiles stringSliceFlag
+	flag.Var(&varFiles, "var-file", "Path to an additional .tfvars file to load. May be repeated; later files take precedence over earlier ones and over auto-discovered .tfvars files.")
+	var varOverrides stringSliceFlag
+	flag.Var(&varOverrides, "var", "A single `key=value` variable override. May be repeated; takes precedence over -var-file.")
+
+	flag.Parse()
+
+	fs := afero.NewOsFs()
+
+	vars, err := tfrender.ResolveVars(fs, *rootDir, varFiles, varOverrides)
+	if err != nil {
+		fmt.Printf("Error resolving variables: %v\n", err)
+		os.Exit(1)
+	}
+
+	plan, err := tfrender.BuildWithVars(fs, *rootDir, vars)
+	if err != nil {
+		fmt.Printf("Error rendering %s: %v\n", *rootDir, err)
+		os.Exit(1)
+	}
+
+	if err := yaml.NewEncoder(os.Stdout).Encode(plan.Vars); err != nil {
+		fmt.Printf("Error encoding YAML: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *dryRun || *check {
+		changed := plan.ChangedFiles()
+		if *dryRun {
+			for _, path := range changed {
+				printDiff(path, plan.Files[path])
+			}
+		}
+		if len(changed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := plan.Apply(fs); err != nil {
+		fmt.Printf("Error writing rendered .tf files: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *watch {
+		if err := watchAndRerender(fs, *rootDir, plan, varFiles, varOverrides); err != nil {
+			fmt.Printf("Error watching %s: %v\n", *rootDir, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// diffText returns a unified diff between a file's original and rendered
+// content, in the same style as `gofmt -l`/`terraform fmt -check` diff
+// output.
+func diffText(path string, file tfrender.RenderedFile) (string, error) {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(file.Original)),
+		B:        difflib.SplitLines(string(file.Rendered)),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	}
+
+	return difflib.GetUnifiedDiffString(diff)
+}
+
+// printDiff writes the unified diff for path to stdout.
+func printDiff(path string, file tfrender.RenderedFile) {
+	text, err := diffText(path, file)
+	if err != nil {
+		fmt.Printf("Error diffing %s: %v\n", path, err)
+		return
+	}
+
+	fmt.Print(text)
+}
+
+// watchAndRerender watches every .tfvars file discovered by the initial
+// render (plus any directory created afterwards, so new .tfvars files are
+// picked up too) and re-renders whenever one changes. It always renders
+// from the original .tf templates captured at startup rather than
+// re-reading the .tf files from disk, since by the time watch mode is
+// running those have already been overwritten with substituted values.
+func watchAndRerender(fs afero.Fs, rootDir string, plan *tfrender.Plan, varFiles, varOverrides []string) error {
+	templates := make(map[string][]byte, len(plan.Files))
+	for path, file := range plan.Files {
+		templates[path] = file.Original
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watchDirs(watcher, fs, rootDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Watching %s for .tfvars changes...\n", rootDir)
+
+	var (
+		mu      sync.Mutex
+		pending = make(map[string]struct{})
+		timer   *time.Timer
+	)
+
+	rerender := func() {
+		mu.Lock()
+		changed := make([]string, 0, len(pending))
+		for path := range pending {
+			changed = append(changed, path)
+		}
+		pending = make(map[string]struct{})
+		mu.Unlock()
+
+		sort.Strings(changed)
+		fmt.Printf("Re-rendering (changed: %s)\n", strings.Join(changed, ", "))
+
+		vars, err := tfrender.ResolveVars(fs, rootDir, varFiles, varOverrides)
+		if err != nil {
+			fmt.Printf("Error resolving variables: %v\n", err)
+			return
+		}
+
+		for path, original := range templates {
+			rendered, err := tfrender.RenderTemplate(vars, path, original)
+			if err != nil {
+				fmt.Printf("Error rendering %s: %v\n", path, err)
+				continue
+			}
+			if err := afero.WriteFile(fs, path, rendered, 0644); err != nil {
+				fmt.Printf("Error writing %s: %v\n", path, err)
+			}
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+
+			if isDir(fs, event.Name) {
+				if event.Op&fsnotify.Create != 0 {
+					_ = watcher.Add(event.Name)
+				}
+				continue
+			}
+
+			if filepath.Ext(event.Name) != ".tfvars" {
+				continue
+			}
+
+			mu.Lock()
+			pending[event.Name] = struct{}{}
+			mu.Unlock()
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounceDelay, rerender)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("Watch error: %v\n", err)
+		}
+	}
+}
+
+// watchDirs registers every directory under root with watcher so that
+// fsnotify, which only watches directories rather than whole trees, still
+// notices files created in subdirectories added after startup.
+func watchDirs(watcher *fsnotify.Watcher, fs afero.Fs, root string) error {
+	return afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+func isDir(fs afero.Fs, path string) bool {
+	info, err := fs.Stat(path)
+	return err == nil && info.IsDir()
+}
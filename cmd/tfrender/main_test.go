@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alileza/tfrender"
+)
+
+func TestDiffText(t *testing.T) {
+	t.Run("unchanged file produces an empty diff", func(t *testing.T) {
+		file := tfrender.RenderedFile{
+			Original: []byte(`name = "hello"`),
+			Rendered: []byte(`name = "hello"`),
+		}
+
+		got, err := diffText("main.tf", file)
+		if err != nil {
+			t.Fatalf("diffText() error = %v", err)
+		}
+		if got != "" {
+			t.Errorf("diffText() = %q, want empty string for an unchanged file", got)
+		}
+	})
+
+	t.Run("changed file produces a unified diff", func(t *testing.T) {
+		file := tfrender.RenderedFile{
+			Original: []byte("resource \"x\" \"y\" {\n  name = var.name\n}\n"),
+			Rendered: []byte("resource \"x\" \"y\" {\n  name = \"hello\"\n}\n"),
+		}
+
+		got, err := diffText("main.tf", file)
+		if err != nil {
+			t.Fatalf("diffText() error = %v", err)
+		}
+
+		for _, want := range []string{
+			"--- main.tf",
+			"+++ main.tf",
+			`-  name = var.name`,
+			`+  name = "hello"`,
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("diffText() missing %q, got:\n%s", want, got)
+			}
+		}
+	})
+}
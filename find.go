@@ -0,0 +1,31 @@
+package tfrender
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// findFiles searches fs for all files with the given extension within root
+// and its subdirectories.
+func findFiles(fs afero.Fs, root, ext string) ([]string, error) {
+	var paths []string
+
+	err := afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if filepath.Ext(info.Name()) == ext {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,244 @@
+// Package render rewrites var.* references in Terraform configuration with
+// literal values, without disturbing anything else about the source file.
+package render
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Renderer substitutes var.<path> traversals found in Terraform source with
+// literal values looked up from Vars.
+type Renderer struct {
+	Vars map[string]any
+}
+
+// New returns a Renderer that resolves var.* traversals against vars.
+func New(vars map[string]any) *Renderer {
+	return &Renderer{Vars: vars}
+}
+
+// Render parses src as HCL and returns a copy of it with every var.<path>
+// traversal replaced by the literal value it resolves to in r.Vars. Dotted
+// and indexed traversals (var.obj.field, var.list[0]) are both supported.
+// Anything else in the file - comments, formatting, unrelated expressions -
+// is preserved exactly as hclwrite read it. filename is only used to
+// annotate parse diagnostics.
+func (r *Renderer) Render(filename string, src []byte) ([]byte, error) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", filename, diags.Error())
+	}
+
+	tokens, err := r.replaceVarTraversals(f.Body().BuildTokens(nil))
+	if err != nil {
+		return nil, fmt.Errorf("rewriting %s: %w", filename, err)
+	}
+
+	return tokens.Bytes(), nil
+}
+
+// replaceVarTraversals walks the token stream looking for TraverseAttr
+// chains rooted at the identifier "var" (optionally continuing through
+// further dotted attributes and bracketed indices) and splices in the
+// formatted tokens for the resolved value in their place.
+func (r *Renderer) replaceVarTraversals(tokens hclwrite.Tokens) (hclwrite.Tokens, error) {
+	out := make(hclwrite.Tokens, 0, len(tokens))
+
+	for i := 0; i < len(tokens); {
+		if !isVarRoot(tokens, i) {
+			out = append(out, tokens[i])
+			i++
+			continue
+		}
+
+		end, path := readVarTraversal(tokens, i)
+
+		val, ok := lookupPath(r.Vars, path)
+		if !ok {
+			// Unknown variable: leave the traversal untouched.
+			out = append(out, tokens[i:end]...)
+			i = end
+			continue
+		}
+
+		ctyVal, err := goValueToCty(val)
+		if err != nil {
+			return nil, fmt.Errorf("var.%s: %w", joinPath(path), err)
+		}
+
+		repl := hclwrite.TokensForValue(ctyVal)
+		if len(repl) > 0 {
+			repl[0].SpacesBefore = tokens[i].SpacesBefore
+		}
+		out = append(out, repl...)
+		i = end
+	}
+
+	return out, nil
+}
+
+// isVarRoot reports whether tokens[i] begins a "var." traversal rooted here,
+// as opposed to a "var" attribute nested under some other traversal (e.g.
+// local.x.var.y) - which is why it also rejects a preceding TokenDot.
+func isVarRoot(tokens hclwrite.Tokens, i int) bool {
+	return tokens[i].Type == hclsyntax.TokenIdent &&
+		string(tokens[i].Bytes) == "var" &&
+		i+1 < len(tokens) &&
+		tokens[i+1].Type == hclsyntax.TokenDot &&
+		(i == 0 || tokens[i-1].Type != hclsyntax.TokenDot)
+}
+
+// readVarTraversal consumes a "var." traversal starting at tokens[i] and
+// returns the index just past it along with the attribute/index path that
+// follows "var" (e.g. var.obj.field[0] -> []any{"obj", "field", 0}).
+func readVarTraversal(tokens hclwrite.Tokens, i int) (int, []any) {
+	var path []any
+	i++ // "var"
+
+	for i < len(tokens) {
+		switch {
+		case tokens[i].Type == hclsyntax.TokenDot && i+1 < len(tokens) && tokens[i+1].Type == hclsyntax.TokenIdent:
+			path = append(path, string(tokens[i+1].Bytes))
+			i += 2
+
+		case tokens[i].Type == hclsyntax.TokenOBrack:
+			seg, next, ok := readIndex(tokens, i)
+			if !ok {
+				return i, path
+			}
+			path = append(path, seg)
+			i = next
+
+		default:
+			return i, path
+		}
+	}
+
+	return i, path
+}
+
+// readIndex reads a "[0]" or `["key"]` index starting at the opening
+// bracket and returns the segment, the index just past the closing bracket,
+// and whether a well-formed index was found.
+func readIndex(tokens hclwrite.Tokens, i int) (any, int, bool) {
+	// [<number>]
+	if i+2 < len(tokens) && tokens[i+1].Type == hclsyntax.TokenNumberLit && tokens[i+2].Type == hclsyntax.TokenCBrack {
+		n, err := parseIndexNumber(string(tokens[i+1].Bytes))
+		if err != nil {
+			return nil, i, false
+		}
+		return n, i + 3, true
+	}
+
+	// ["<key>"]
+	if i+4 < len(tokens) &&
+		tokens[i+1].Type == hclsyntax.TokenOQuote &&
+		tokens[i+2].Type == hclsyntax.TokenQuotedLit &&
+		tokens[i+3].Type == hclsyntax.TokenCQuote &&
+		tokens[i+4].Type == hclsyntax.TokenCBrack {
+		return string(tokens[i+2].Bytes), i + 5, true
+	}
+
+	return nil, i, false
+}
+
+func parseIndexNumber(s string) (int, error) {
+	var n int
+	if _, err := fmt.Sscanf(s, "%d", &n); err != nil {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	return n, nil
+}
+
+// lookupPath walks vars following path, which is a mix of string keys (map
+// access) and int indices (list access).
+func lookupPath(vars map[string]any, path []any) (any, bool) {
+	var cur any = vars
+
+	for _, seg := range path {
+		switch s := seg.(type) {
+		case string:
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			cur, ok = m[s]
+			if !ok {
+				return nil, false
+			}
+		case int:
+			list, ok := cur.([]any)
+			if !ok || s < 0 || s >= len(list) {
+				return nil, false
+			}
+			cur = list[s]
+		}
+	}
+
+	return cur, true
+}
+
+func joinPath(path []any) string {
+	s := ""
+	for i, seg := range path {
+		switch v := seg.(type) {
+		case string:
+			if i > 0 {
+				s += "."
+			}
+			s += v
+		case int:
+			s += fmt.Sprintf("[%d]", v)
+		}
+	}
+	return s
+}
+
+// goValueToCty converts the plain Go values produced by tfrender's tfvars
+// loader (string, bool, float64, []any, map[string]any, nil) into the
+// equivalent cty.Value so they can be formatted back into HCL tokens.
+func goValueToCty(v any) (cty.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case string:
+		return cty.StringVal(val), nil
+	case bool:
+		return cty.BoolVal(val), nil
+	case float64:
+		return cty.NumberFloatVal(val), nil
+	case []any:
+		if len(val) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		vals := make([]cty.Value, len(val))
+		for i, e := range val {
+			cv, err := goValueToCty(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[i] = cv
+		}
+		return cty.TupleVal(vals), nil
+	case map[string]any:
+		if len(val) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		vals := make(map[string]cty.Value, len(val))
+		for k, e := range val {
+			cv, err := goValueToCty(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[k] = cv
+		}
+		return cty.ObjectVal(vals), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value type %T", v)
+	}
+}
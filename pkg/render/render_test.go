@@ -0,0 +1,60 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	vars := map[string]any{
+		"name":    "hello",
+		"count":   float64(3),
+		"enabled": true,
+		"tags": map[string]any{
+			"env": "prod",
+		},
+		"list": []any{"first", "second"},
+	}
+
+	src := `resource "null_resource" "example" {
+  # this comment mentions var.name and must survive untouched
+  name        = var.name
+  count       = var.count
+  enabled     = var.enabled
+  nested_attr = var.tags.env
+  first_item  = var.list[0]
+  unknown     = var.does_not_exist
+  nested_var  = local.something.var.name
+  indexed_var = foo[0].var.bar
+}
+`
+
+	out, err := New(vars).Render("sample.tf", []byte(src))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		`name        = "hello"`,
+		`count       = 3`,
+		`enabled     = true`,
+		`nested_attr = "prod"`,
+		`first_item  = "first"`,
+		"var.does_not_exist",
+		"this comment mentions var.name and must survive untouched",
+		"local.something.var.name",
+		"foo[0].var.bar",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() output missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderer_Render_InvalidHCL(t *testing.T) {
+	_, err := New(nil).Render("broken.tf", []byte(`resource "x" "y" {`))
+	if err == nil {
+		t.Fatal("Render() expected an error for invalid HCL, got nil")
+	}
+}
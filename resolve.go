@@ -0,0 +1,62 @@
+package tfrender
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// envVarPrefix is the prefix Terraform itself uses for environment variable
+// overrides, e.g. TF_VAR_name=value.
+const envVarPrefix = "TF_VAR_"
+
+// ResolveVars merges variables from every source tfrender supports, in
+// Terraform's own precedence order: auto-discovered .tfvars files under
+// root, then varFiles in the order given, then varOverrides ("key=value"
+// strings, as from a repeatable -var flag), then TF_VAR_* environment
+// variables - each source overriding the last.
+func ResolveVars(fs afero.Fs, root string, varFiles, varOverrides []string) (map[string]any, error) {
+	vars, err := MergeVars(fs, root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range varFiles {
+		fileVars, err := ParseTFVarsFile(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("-var-file %s: %w", path, err)
+		}
+		for key, value := range fileVars {
+			vars[key] = value
+		}
+	}
+
+	for _, kv := range varOverrides {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("-var %q: expected key=value", kv)
+		}
+		parsed, err := ParseHCLValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("-var %s: %w", key, err)
+		}
+		vars[key] = parsed
+	}
+
+	for _, env := range os.Environ() {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(key, envVarPrefix) {
+			continue
+		}
+
+		parsed, err := ParseHCLValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		vars[strings.TrimPrefix(key, envVarPrefix)] = parsed
+	}
+
+	return vars, nil
+}
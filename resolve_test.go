@@ -0,0 +1,93 @@
+package tfrender
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestResolveVars(t *testing.T) {
+	setup := func(t *testing.T) afero.Fs {
+		t.Helper()
+		fs := afero.NewMemMapFs()
+		if err := afero.WriteFile(fs, "env/main.tfvars", []byte(`name = "from-tfvars"
+env  = "dev"
+`), 0644); err != nil {
+			t.Fatalf("seeding env/main.tfvars: %v", err)
+		}
+		if err := afero.WriteFile(fs, "override.tfvars", []byte(`env = "from-var-file"`), 0644); err != nil {
+			t.Fatalf("seeding override.tfvars: %v", err)
+		}
+		return fs
+	}
+
+	t.Run("auto-discovered tfvars is the base", func(t *testing.T) {
+		fs := setup(t)
+		vars, err := ResolveVars(fs, "env", nil, nil)
+		if err != nil {
+			t.Fatalf("ResolveVars() error = %v", err)
+		}
+		if vars["name"] != "from-tfvars" || vars["env"] != "dev" {
+			t.Errorf("vars = %v, want name=from-tfvars env=dev", vars)
+		}
+	})
+
+	t.Run("-var-file overrides auto-discovered tfvars", func(t *testing.T) {
+		fs := setup(t)
+		vars, err := ResolveVars(fs, "env", []string{"override.tfvars"}, nil)
+		if err != nil {
+			t.Fatalf("ResolveVars() error = %v", err)
+		}
+		if vars["env"] != "from-var-file" {
+			t.Errorf("vars[env] = %v, want from-var-file", vars["env"])
+		}
+		if vars["name"] != "from-tfvars" {
+			t.Errorf("vars[name] = %v, want from-tfvars (untouched)", vars["name"])
+		}
+	})
+
+	t.Run("-var overrides -var-file", func(t *testing.T) {
+		fs := setup(t)
+		vars, err := ResolveVars(fs, "env", []string{"override.tfvars"}, []string{"env=from-var-flag"})
+		if err != nil {
+			t.Fatalf("ResolveVars() error = %v", err)
+		}
+		if vars["env"] != "from-var-flag" {
+			t.Errorf("vars[env] = %v, want from-var-flag", vars["env"])
+		}
+	})
+
+	t.Run("TF_VAR_* overrides -var", func(t *testing.T) {
+		fs := setup(t)
+		t.Setenv("TF_VAR_env", "from-environment")
+		vars, err := ResolveVars(fs, "env", []string{"override.tfvars"}, []string{"env=from-var-flag"})
+		if err != nil {
+			t.Fatalf("ResolveVars() error = %v", err)
+		}
+		if vars["env"] != "from-environment" {
+			t.Errorf("vars[env] = %v, want from-environment", vars["env"])
+		}
+	})
+
+	t.Run("-var value is parsed as HCL", func(t *testing.T) {
+		fs := setup(t)
+		vars, err := ResolveVars(fs, "env", nil, []string{"count=3", `tags={env="prod"}`})
+		if err != nil {
+			t.Fatalf("ResolveVars() error = %v", err)
+		}
+		if vars["count"] != float64(3) {
+			t.Errorf("vars[count] = %v (%T), want 3 (float64)", vars["count"], vars["count"])
+		}
+		tags, ok := vars["tags"].(map[string]any)
+		if !ok || tags["env"] != "prod" {
+			t.Errorf("vars[tags] = %v, want map[env:prod]", vars["tags"])
+		}
+	})
+
+	t.Run("malformed -var returns an error", func(t *testing.T) {
+		fs := setup(t)
+		if _, err := ResolveVars(fs, "env", nil, []string{"no-equals-sign"}); err == nil {
+			t.Fatal("ResolveVars() expected an error for a -var without '=', got nil")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// Package tfrender finds .tfvars files under a directory tree, merges their
+// values, and substitutes the resulting variables into every .tf file it
+// finds there. It is built on afero so the whole pipeline can run against an
+// in-memory filesystem for tests and preview modes, not just the real disk.
+package tfrender
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/alileza/tfrender/pkg/render"
+	"github.com/spf13/afero"
+)
+
+// RenderedFile is a .tf file before and after variable substitution.
+type RenderedFile struct {
+	Original []byte
+	Rendered []byte
+}
+
+// Plan is the result of rendering a directory tree: the variables the .tf
+// files were rendered with (for Build, those merged from every .tfvars file
+// found; for BuildWithVars, the map it was given), and the rewritten content
+// of every .tf file, keyed by path. Nothing is written to fs until Apply is
+// called.
+type Plan struct {
+	Vars  map[string]any
+	Files map[string]RenderedFile
+}
+
+// FindFiles returns every file with the given extension under root on fs.
+// It is exposed so callers that need the raw file lists - e.g. a watch mode
+// that sets up filesystem watches on every discovered .tfvars file - don't
+// have to duplicate the walk.
+func FindFiles(fs afero.Fs, root, ext string) ([]string, error) {
+	return findFiles(fs, root, ext)
+}
+
+// MergeVars finds every .tfvars file under root on fs and merges their
+// top-level values into a single map, later files taking precedence over
+// earlier ones.
+func MergeVars(fs afero.Fs, root string) (map[string]any, error) {
+	tfvarsPaths, err := findFiles(fs, root, ".tfvars")
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]any)
+	for _, path := range tfvarsPaths {
+		tfvarsMap, err := ParseTFVarsFile(fs, path)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range tfvarsMap {
+			vars[key] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// RenderTemplate substitutes var.* references in content against vars.
+// Callers that re-render repeatedly (e.g. watch mode) should keep their own
+// cached copy of the original, unrendered template and always pass that in,
+// rather than re-reading a file tfrender has already rewritten in place.
+func RenderTemplate(vars map[string]any, path string, content []byte) ([]byte, error) {
+	return render.New(vars).Render(path, content)
+}
+
+// Build finds every .tfvars and .tf file under root on fs, merges the
+// .tfvars values into a single map, and renders each .tf file's var.*
+// references against it. It does not write anything back to fs, which makes
+// it safe to call against a real filesystem for a dry-run or diff, or
+// against afero.NewMemMapFs() for tests.
+func Build(fs afero.Fs, root string) (*Plan, error) {
+	vars, err := MergeVars(fs, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildWithVars(fs, root, vars)
+}
+
+// BuildWithVars is like Build, but takes an already-computed variable map
+// instead of merging the root's .tfvars files itself. It lets callers layer
+// higher-precedence variable sources - -var-file, -var, TF_VAR_* - on top
+// of the auto-discovered .tfvars values before rendering.
+func BuildWithVars(fs afero.Fs, root string, vars map[string]any) (*Plan, error) {
+	tfPaths, err := findFiles(fs, root, ".tf")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]RenderedFile, len(tfPaths))
+	for _, path := range tfPaths {
+		original, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered, err := RenderTemplate(vars, path, original)
+		if err != nil {
+			return nil, err
+		}
+
+		files[path] = RenderedFile{Original: original, Rendered: rendered}
+	}
+
+	return &Plan{Vars: vars, Files: files}, nil
+}
+
+// Apply writes every rendered .tf file in the plan back to fs.
+func (p *Plan) Apply(fs afero.Fs) error {
+	for path, file := range p.Files {
+		if err := afero.WriteFile(fs, path, file.Rendered, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChangedFiles returns the paths, in sorted order, of every .tf file in the
+// plan whose rendered content differs from its original content. Callers
+// use this to support dry-run/check modes without writing anything to fs.
+func (p *Plan) ChangedFiles() []string {
+	var changed []string
+	for path, file := range p.Files {
+		if !bytes.Equal(file.Original, file.Rendered) {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Render builds a Plan for root on fs and applies it, overwriting every .tf
+// file's var.* references in place. It is the simplest library entry point:
+// only the .tfvars files found under root are used, with no -var-file, -var
+// or TF_VAR_* overrides (cmd/tfrender layers those on via ResolveVars and
+// BuildWithVars instead).
+func Render(fs afero.Fs, root string) error {
+	plan, err := Build(fs, root)
+	if err != nil {
+		return err
+	}
+	return plan.Apply(fs)
+}
@@ -0,0 +1,126 @@
+package tfrender
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantVars  map[string]any
+		wantInTF  string
+		tfPath    string
+		wantError bool
+	}{
+		{
+			name: "merges tfvars and substitutes into tf",
+			files: map[string]string{
+				"env/main.tfvars": `name = "hello"
+count = 3
+`,
+				"env/main.tf": `resource "null_resource" "example" {
+  name  = var.name
+  count = var.count
+}
+`,
+			},
+			wantVars: map[string]any{"name": "hello", "count": float64(3)},
+			tfPath:   "env/main.tf",
+			wantInTF: `name  = "hello"`,
+		},
+		{
+			name: "invalid tfvars returns an error",
+			files: map[string]string{
+				"env/main.tfvars": `name = `,
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for path, content := range tt.files {
+				if err := afero.WriteFile(fs, path, []byte(content), 0644); err != nil {
+					t.Fatalf("seeding %s: %v", path, err)
+				}
+			}
+
+			plan, err := Build(fs, "env")
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("Build() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			for key, want := range tt.wantVars {
+				if got := plan.Vars[key]; got != want {
+					t.Errorf("Vars[%q] = %v, want %v", key, got, want)
+				}
+			}
+
+			if tt.tfPath != "" {
+				got := string(plan.Files[tt.tfPath].Rendered)
+				if !strings.Contains(got, tt.wantInTF) {
+					t.Errorf("rendered %s = %q, want substring %q", tt.tfPath, got, tt.wantInTF)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"env/main.tfvars": `name = "hello"`,
+		"env/main.tf":     `name = var.name`,
+	}
+	for path, content := range files {
+		if err := afero.WriteFile(fs, path, []byte(content), 0644); err != nil {
+			t.Fatalf("seeding %s: %v", path, err)
+		}
+	}
+
+	if err := Render(fs, "env"); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	got, err := afero.ReadFile(fs, "env/main.tf")
+	if err != nil {
+		t.Fatalf("reading rendered file: %v", err)
+	}
+	want := `name = "hello"`
+	if string(got) != want {
+		t.Errorf("env/main.tf = %q, want %q", got, want)
+	}
+}
+
+func TestPlanChangedFiles(t *testing.T) {
+	plan := &Plan{
+		Files: map[string]RenderedFile{
+			"env/unchanged.tf": {Original: []byte(`name = "hello"`), Rendered: []byte(`name = "hello"`)},
+			"env/b.tf":         {Original: []byte(`name = var.name`), Rendered: []byte(`name = "hello"`)},
+			"env/a.tf":         {Original: []byte(`env = var.env`), Rendered: []byte(`env = "prod"`)},
+		},
+	}
+
+	got := plan.ChangedFiles()
+	want := []string{"env/a.tf", "env/b.tf"}
+
+	if len(got) != len(want) {
+		t.Fatalf("ChangedFiles() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ChangedFiles()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tfrender
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// tfvarsEvalContext is shared across all .tfvars files so that the handful of
+// functions Terraform itself makes available in variable definitions (e.g.
+// jsonencode) also work here.
+var tfvarsEvalContext = &hcl.EvalContext{
+	Functions: map[string]function.Function{
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"jsondecode": stdlib.JSONDecodeFunc,
+	},
+}
+
+// ParseTFVarsFile parses a .tfvars file with a full HCL parser and returns a
+// map of the top-level key-value pairs. Using hclparse/hclsyntax instead of a
+// hand-rolled scanner means heredocs, multi-line lists of objects, nested
+// maps, typed values and function calls (e.g. jsonencode(...)) all parse the
+// same way Terraform itself parses them. It is exported so callers can load
+// an extra tfvars file (e.g. a `-var-file` flag) on top of the files
+// auto-discovered under the render root.
+func ParseTFVarsFile(fs afero.Fs, filePath string) (map[string]any, error) {
+	src, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCL(src, filePath)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", filePath, diags.Error())
+	}
+
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", filePath, diags.Error())
+	}
+
+	tfvarsMap := make(map[string]any, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(tfvarsEvalContext)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("evaluating %s in %s: %s", name, filePath, diags.Error())
+		}
+
+		goVal, err := ctyValueToGo(val)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s in %s: %w", name, filePath, err)
+		}
+		tfvarsMap[name] = goVal
+	}
+
+	return tfvarsMap, nil
+}
+
+// ParseHCLValue evaluates a single HCL expression, such as the value half of
+// a `-var key=value` flag or a TF_VAR_name environment variable, into the
+// same plain Go types a .tfvars attribute decodes to. If expr isn't a valid
+// HCL expression (or references something that can't be evaluated, e.g. an
+// undefined variable), it falls back to treating expr as a plain string,
+// the same leniency Terraform itself affords `-var foo=bar` without quotes.
+func ParseHCLValue(expr string) (any, error) {
+	e, diags := hclsyntax.ParseExpression([]byte(expr), "<value>", hcl.InitialPos)
+	if !diags.HasErrors() {
+		val, valDiags := e.Value(tfvarsEvalContext)
+		if !valDiags.HasErrors() {
+			return ctyValueToGo(val)
+		}
+	}
+
+	return expr, nil
+}
+
+// ctyValueToGo converts an evaluated HCL expression into the plain Go types
+// (string, bool, float64, []any, map[string]any) that the rest of tfrender
+// merges and substitutes with.
+func ctyValueToGo(val cty.Value) (any, error) {
+	if val.IsNull() {
+		return nil, nil
+	}
+
+	t := val.Type()
+	switch {
+	case t == cty.String:
+		return val.AsString(), nil
+	case t == cty.Bool:
+		return val.True(), nil
+	case t == cty.Number:
+		f, _ := val.AsBigFloat().Float64()
+		return f, nil
+	case t.IsTupleType() || t.IsListType() || t.IsSetType():
+		items := make([]any, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			goVal, err := ctyValueToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, goVal)
+		}
+		return items, nil
+	case t.IsObjectType() || t.IsMapType():
+		m := make(map[string]any, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			kv, ev := it.Element()
+			goVal, err := ctyValueToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+			m[kv.AsString()] = goVal
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported HCL value type %s", t.FriendlyName())
+	}
+}